@@ -3,9 +3,8 @@ package main
 import (
 	"errors"
 	"flag"
-	"os"
 
-	"github.com/The-Debarghya/gloomy"
+	gloomy "github.com/The-Debarghya/gloomy"
 )
 
 const logPath = "./example.log"
@@ -19,13 +18,13 @@ func doSomething() error {
 func main() {
 	flag.Parse()
 
-	lf, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0660)
+	fileSink, err := gloomy.NewFileSink(logPath,
+		gloomy.WithMaxSizeBytes(10<<20), gloomy.WithMaxBackups(5), gloomy.WithCompress(true))
 	if err != nil {
 		gloomy.Fatalf("Failed to open log file: %v", err)
 	}
-	defer lf.Close()
 
-	defer gloomy.Init("GloomyExample", *verbose, true, lf).Close()
+	defer gloomy.Init("GloomyExample", *verbose, fileSink).Close()
 
 	gloomy.Info("I'm about to do something!")
 	if err := doSomething(); err != nil {
@@ -2,10 +2,11 @@ package gologger
 
 import (
 	"fmt"
-	"io"
 	"log"
 	"os"
 	"sync"
+
+	"github.com/go-logr/logr"
 )
 
 // severity of logs
@@ -21,9 +22,41 @@ type Logger struct {
 	warnLog     *log.Logger
 	errorLog    *log.Logger
 	fatalLog    *log.Logger
-	closers     []io.Closer
+	sinks       []Sink
 	initialized bool
 	level       Level
+
+	// backend is an optional logr.Logger installed via SetLogger. When set,
+	// output() and outputS() route formatted records to it instead of (or,
+	// with WriteBuffer, in addition to) the log.Logger writers above.
+	backend     logr.Logger
+	hasBackend  bool
+	writeBuffer bool
+
+	// name and values are inherited by child Loggers returned from
+	// WithName/WithValues, mirroring the same fields on the installed
+	// logr.Logger backend. values are added to every structured (*S) record
+	// and to every record delivered to the backend; name is not written to
+	// the text or formatted output.
+	name   string
+	values []interface{}
+
+	// vmodule holds the compiled -vmodule patterns installed by SetVModule,
+	// and siteCache memoizes the effective Level for each call site (keyed
+	// by PC) so repeated Verbosity/VDepth calls skip pattern matching.
+	// Both are guarded by logLock; siteCache itself is safe for lock-free
+	// concurrent reads/writes once obtained.
+	vmodule   []vmodulePattern
+	siteCache *sync.Map
+
+	// asyncSinks holds one asyncSink per severity below the FlushSeverity
+	// configured via InitWithOptions/BufferSize, or all nils when
+	// buffering wasn't enabled.
+	asyncSinks [4]*asyncSink
+
+	// formatter, if set via SetFormatter, takes over rendering records and
+	// writing them to sinks, bypassing the log.Logger text path below.
+	formatter Formatter
 }
 
 type Verbose struct {
@@ -59,10 +92,11 @@ var (
 // reset default logger for tests to reset environment
 func init_logger() {
 	defaultLogger = &Logger{
-		infoLog:  log.New(os.Stderr, initText+labelInfo, flags),
-		warnLog:  log.New(os.Stderr, initText+labelWarn, flags),
-		errorLog: log.New(os.Stderr, initText+labelErr, flags),
-		fatalLog: log.New(os.Stderr, initText+labelFatal, flags),
+		infoLog:   log.New(os.Stderr, initText+labelInfo, flags),
+		warnLog:   log.New(os.Stderr, initText+labelWarn, flags),
+		errorLog:  log.New(os.Stderr, initText+labelErr, flags),
+		fatalLog:  log.New(os.Stderr, initText+labelFatal, flags),
+		siteCache: &sync.Map{},
 	}
 }
 
@@ -76,53 +110,30 @@ Default log functions can be called before Init(),
 but log output will only go to stderr (along with a warning).
 The first call to Init populates the default logger and returns the
 generated logger, subsequent calls to Init will only return the generated
-logger. If the logFd passed in also satisfies io.Closer, logFd.Close will be called
-when closing the logger.
+logger. Init always writes Error/Fatal records to stderr in addition to
+sinks; if verbose, Info/Warning records are also echoed to stdout. sinks
+are composed alongside those defaults, each filtered to the severities its
+own MinSeverity/MaxSeverity options accept, and are closed/flushed by
+Close/Flush.
 */
-func Init(name string, verbose, systemLog bool, logFd io.Writer) *Logger {
-	var il, wl, el io.Writer
-	var syslogErr error
-	if systemLog {
-		il, wl, el, syslogErr = setup(name)
-	}
-
-	iLogs := []io.Writer{logFd}
-	wLogs := []io.Writer{logFd}
-	eLogs := []io.Writer{logFd}
-	if il != nil {
-		iLogs = append(iLogs, il)
-	}
-	if wl != nil {
-		wLogs = append(wLogs, wl)
-	}
-	if el != nil {
-		eLogs = append(eLogs, el)
-	}
-
-	eLogs = append(eLogs, os.Stderr)
+func Init(name string, verbose bool, sinks ...Sink) *Logger {
+	allSinks := append([]Sink{}, sinks...)
+	allSinks = append(allSinks, NewWriterSink(nopCloseWriter{os.Stderr}, MinSeverity(SeverityError)))
 	if verbose {
-		iLogs = append(iLogs, os.Stdout)
-		wLogs = append(wLogs, os.Stdout)
+		allSinks = append(allSinks, NewWriterSink(nopCloseWriter{os.Stdout}, MinSeverity(SeverityInfo), MaxSeverity(SeverityWarning)))
 	}
 
 	l := Logger{
-		infoLog:  log.New(io.MultiWriter(iLogs...), labelInfo, flags),
-		warnLog:  log.New(io.MultiWriter(wLogs...), labelWarn, flags),
-		errorLog: log.New(io.MultiWriter(eLogs...), labelErr, flags),
-		fatalLog: log.New(io.MultiWriter(eLogs...), labelFatal, flags),
-	}
-	for _, w := range []io.Writer{logFd, il, wl, el} {
-		c, ok := w.(io.Closer)
-		if ok && c != nil {
-			l.closers = append(l.closers, c)
-		}
+		infoLog:   log.New(&sinkFanout{severity: SeverityInfo, sinks: allSinks}, labelInfo, flags),
+		warnLog:   log.New(&sinkFanout{severity: SeverityWarning, sinks: allSinks}, labelWarn, flags),
+		errorLog:  log.New(&sinkFanout{severity: SeverityError, sinks: allSinks}, labelErr, flags),
+		fatalLog:  log.New(&sinkFanout{severity: SeverityFatal, sinks: allSinks}, labelFatal, flags),
+		sinks:     allSinks,
+		name:      name,
+		siteCache: &sync.Map{},
 	}
 	l.initialized = true
 
-	if syslogErr != nil {
-		l.Error(syslogErr)
-	}
-
 	logLock.Lock()
 	defer logLock.Unlock()
 	if !defaultLogger.initialized {
@@ -141,6 +152,23 @@ func Close() {
 func (l *Logger) output(s severity, depth int, txt string) {
 	logLock.Lock()
 	defer logLock.Unlock()
+	if l.hasBackend {
+		l.deliverToBackend(s, nil, txt, nil)
+		if !l.writeBuffer {
+			return
+		}
+	}
+	if l.formatter != nil {
+		l.writeFormatted(s, depth+1, txt, nil)
+		return
+	}
+	l.writeText(s, depth+1, txt)
+}
+
+// writeText writes txt to the underlying log.Logger writers for severity s.
+// Callers must hold logLock. It never consults the logr backend, since
+// callers that need backend delivery do so themselves before reaching here.
+func (l *Logger) writeText(s severity, depth int, txt string) {
 	switch s {
 	case sInfo:
 		l.infoLog.Output(3+depth, txt)
@@ -155,6 +183,24 @@ func (l *Logger) output(s severity, depth int, txt string) {
 	}
 }
 
+// deliverToBackend forwards a record to the installed logr backend. Callers
+// must hold logLock and must only call this when l.hasBackend is true.
+// Warning has no direct logr equivalent, so it is delivered as Info tagged
+// with a "severity" key; Fatal is delivered as Error since the process is
+// about to exit.
+func (l *Logger) deliverToBackend(s severity, err error, msg string, kv []interface{}) {
+	logger := l.backend
+	allKV := append(append([]interface{}{}, l.values...), kv...)
+	switch s {
+	case sError, sFatal:
+		logger.Error(err, msg, allKV...)
+	case sWarn:
+		logger.Info(msg, append(allKV, "severity", "warning")...)
+	default:
+		logger.Info(msg, allKV...)
+	}
+}
+
 /*
 Close closes all log writers and will flush any cached logs.
 Errors from closing the underlying log writers will be printed to stderr.
@@ -168,9 +214,11 @@ func (l *Logger) Close() {
 		return
 	}
 
-	for _, c := range l.closers {
-		if err := c.Close(); err != nil {
-			fmt.Fprintf(os.Stderr, "[ERROR]: Failed to close log %v: %v\n", c, err)
+	l.stopAsync()
+
+	for _, s := range l.sinks {
+		if err := s.Close(); err != nil {
+			logSinkError("close", err)
 		}
 	}
 }
@@ -407,18 +455,16 @@ func SetLevel(lvl Level) {
 /* VERBOSE LOGGING */
 
 // Verbosity generates a log record depends on the setting of the Level; or none default.
-// It uses the specified logger.
+// It uses the specified logger. If a -vmodule pattern set with SetVModule
+// matches the caller's source file, that pattern's level overrides l.level.
 func (l *Logger) Verbosity(lvl Level) Verbose {
-	return Verbose{
-		enabled: l.level >= lvl,
-		logger:  l,
-	}
+	return l.VDepth(1, lvl)
 }
 
 // Verbosity generates a log record, depends on the setting of the Level; or none
 // by default using the default logger.
 func Verbosity(lvl Level) Verbose {
-	return defaultLogger.Verbosity(lvl)
+	return defaultLogger.VDepth(1, lvl)
 }
 
 // Info is equivalent to Info function, when verbosity(v) is enabled.
@@ -0,0 +1,241 @@
+package gologger
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+)
+
+// Severity identifies a log level for use in public APIs such as
+// FlushSeverity, where the package's own unexported severity type isn't
+// available to callers.
+type Severity int
+
+// Severity values, in the same order as the package's internal severity
+// levels.
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+	SeverityFatal
+)
+
+const defaultFlushInterval = time.Second
+
+// InitOption configures optional behavior for InitWithOptions, such as
+// asynchronous buffering of the write path.
+type InitOption func(*bufferConfig)
+
+type bufferConfig struct {
+	bufferSize    int
+	flushInterval time.Duration
+	flushSeverity severity
+}
+
+// BufferSize enables an in-memory buffer of up to n bytes per severity
+// below FlushSeverity, drained by a background flush goroutine instead of
+// writing synchronously on every call. A BufferSize of 0 (the default)
+// disables buffering entirely.
+func BufferSize(n int) InitOption {
+	return func(c *bufferConfig) {
+		c.bufferSize = n
+	}
+}
+
+// FlushInterval sets how often the background flush goroutine drains
+// buffered records to their underlying writer, one Write call per record.
+// Defaults to one second.
+func FlushInterval(d time.Duration) InitOption {
+	return func(c *bufferConfig) {
+		c.flushInterval = d
+	}
+}
+
+// FlushSeverity sets the minimum severity that bypasses buffering and is
+// always written synchronously, so that Error and Fatal records are never
+// lost if the process crashes before the next flush. Defaults to
+// SeverityError.
+func FlushSeverity(s Severity) InitOption {
+	return func(c *bufferConfig) {
+		c.flushSeverity = severity(s)
+	}
+}
+
+// InitWithOptions is like Init but additionally accepts InitOptions such as
+// BufferSize to enable asynchronous buffered writes.
+func InitWithOptions(name string, verbose bool, sinks []Sink, opts ...InitOption) *Logger {
+	l := Init(name, verbose, sinks...)
+
+	cfg := bufferConfig{
+		flushInterval: defaultFlushInterval,
+		flushSeverity: sError,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.bufferSize > 0 {
+		l.enableAsync(cfg)
+	}
+	return l
+}
+
+// enableAsync wraps the writers of every severity below cfg.flushSeverity
+// with an asyncSink, so Info/Warning (by default) are buffered and flushed
+// periodically while Error/Fatal keep writing synchronously.
+func (l *Logger) enableAsync(cfg bufferConfig) {
+	logLock.Lock()
+	defer logLock.Unlock()
+
+	for _, lg := range []struct {
+		s      severity
+		logger *log.Logger
+	}{
+		{sInfo, l.infoLog},
+		{sWarn, l.warnLog},
+		{sError, l.errorLog},
+		{sFatal, l.fatalLog},
+	} {
+		if lg.s >= cfg.flushSeverity {
+			continue
+		}
+		sink := newAsyncSink(lg.logger.Writer(), cfg.bufferSize, cfg.flushInterval)
+		lg.logger.SetOutput(sink)
+		l.asyncSinks[lg.s] = sink
+	}
+}
+
+// Flush blocks until any asynchronously buffered records have been written
+// to their underlying writers, then flushes every configured Sink.
+func (l *Logger) Flush() {
+	logLock.Lock()
+	asyncSinks := l.asyncSinks
+	sinks := l.sinks
+	logLock.Unlock()
+
+	for _, s := range asyncSinks {
+		if s != nil {
+			s.flush()
+		}
+	}
+	for _, s := range sinks {
+		if err := s.Flush(); err != nil {
+			logSinkError("flush", err)
+		}
+	}
+}
+
+// Flush blocks until the default logger's asynchronously buffered records
+// have been written to their underlying writers.
+func Flush() {
+	defaultLogger.Flush()
+}
+
+// stopAsync flushes and stops every asyncSink installed by enableAsync.
+// Callers must hold logLock.
+func (l *Logger) stopAsync() {
+	for i, s := range l.asyncSinks {
+		if s != nil {
+			s.close()
+			l.asyncSinks[i] = nil
+		}
+	}
+}
+
+// asyncSink is a bounded, in-memory staging buffer for one severity's
+// writer. Writes accumulate as distinct records until either the buffered
+// bytes would exceed capacity (in which case they are dropped and counted)
+// or the background goroutine's ticker fires, at which point every staged
+// record is flushed to target with its own Write call, so target (in
+// practice a sinkFanout, possibly wrapping a Sink such as JournaldSink that
+// treats one Write as one record) never sees two records coalesced into
+// one.
+type asyncSink struct {
+	mu      sync.Mutex
+	records [][]byte
+	size    int
+	cap     int
+	dropped int
+
+	target   io.Writer
+	interval time.Duration
+	stop     chan struct{}
+	done     sync.WaitGroup
+}
+
+func newAsyncSink(target io.Writer, bufSize int, interval time.Duration) *asyncSink {
+	if interval <= 0 {
+		interval = defaultFlushInterval
+	}
+	a := &asyncSink{
+		cap:      bufSize,
+		target:   target,
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+	a.done.Add(1)
+	go a.run()
+	return a
+}
+
+// Write implements io.Writer by staging a copy of p as its own record. It
+// never blocks: once the buffered size would exceed cap, p is dropped and
+// counted, surfaced as a synthetic "messages dropped" record on the next
+// flush.
+func (a *asyncSink) Write(p []byte) (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.size+len(p) > a.cap {
+		a.dropped++
+		return len(p), nil
+	}
+	a.records = append(a.records, append([]byte(nil), p...))
+	a.size += len(p)
+	return len(p), nil
+}
+
+func (a *asyncSink) run() {
+	defer a.done.Done()
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			a.flush()
+		case <-a.stop:
+			a.flush()
+			return
+		}
+	}
+}
+
+// flush writes every staged record to target with its own Write call, plus
+// a synthetic drop-count record of its own if any writes were dropped
+// since the last flush, preserving target's one-record-per-Write contract.
+func (a *asyncSink) flush() {
+	a.mu.Lock()
+	if len(a.records) == 0 && a.dropped == 0 {
+		a.mu.Unlock()
+		return
+	}
+	records := a.records
+	dropped := a.dropped
+	a.records = nil
+	a.size = 0
+	a.dropped = 0
+	a.mu.Unlock()
+
+	for _, rec := range records {
+		a.target.Write(rec)
+	}
+	if dropped > 0 {
+		fmt.Fprintf(a.target, "%s%d messages dropped, log buffer was full\n", labelWarn, dropped)
+	}
+}
+
+// close stops the background flush goroutine after a final flush.
+func (a *asyncSink) close() {
+	close(a.stop)
+	a.done.Wait()
+}
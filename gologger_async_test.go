@@ -0,0 +1,130 @@
+package gologger
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInitWithOptionsBuffersBelowFlushSeverity(t *testing.T) {
+	var buf strings.Builder
+	l := InitWithOptions("async-test", false, []Sink{NewWriterSink(&buf)},
+		BufferSize(4096), FlushInterval(time.Hour), FlushSeverity(SeverityError))
+	defer l.Close()
+
+	l.Info("buffered message")
+	if strings.Contains(buf.String(), "buffered message") {
+		t.Fatal("expected Info output to stay buffered before a flush")
+	}
+
+	l.Flush()
+	if !strings.Contains(buf.String(), "buffered message") {
+		t.Error("expected Flush to write the buffered message")
+	}
+}
+
+func TestInitWithOptionsBypassesFlushSeverity(t *testing.T) {
+	var buf strings.Builder
+	l := InitWithOptions("async-test-bypass", false, []Sink{NewWriterSink(&buf)},
+		BufferSize(4096), FlushInterval(time.Hour), FlushSeverity(SeverityError))
+	defer l.Close()
+
+	l.Error("urgent message")
+	if !strings.Contains(buf.String(), "urgent message") {
+		t.Error("expected Error output at/above FlushSeverity to be written synchronously")
+	}
+}
+
+// countingSink records every Write call it receives, so tests can assert
+// on record boundaries instead of just the concatenated output.
+type countingSink struct {
+	writes [][]byte
+}
+
+func (s *countingSink) Write(_ Severity, p []byte) error {
+	s.writes = append(s.writes, append([]byte(nil), p...))
+	return nil
+}
+func (s *countingSink) Close() error { return nil }
+func (s *countingSink) Flush() error { return nil }
+
+func TestAsyncSinkFlushPreservesRecordBoundaries(t *testing.T) {
+	sink := &countingSink{}
+	l := InitWithOptions("async-test-boundaries", false, []Sink{sink},
+		BufferSize(4096), FlushInterval(time.Hour), FlushSeverity(SeverityError))
+	defer l.Close()
+
+	l.Info("first")
+	l.Info("second")
+	l.Info("third")
+	l.Flush()
+
+	if len(sink.writes) != 3 {
+		t.Fatalf("expected 3 distinct Sink.Write calls, one per record, got %d: %q", len(sink.writes), sink.writes)
+	}
+}
+
+// TestInitWithOptionsCloseDoesNotCloseStderr guards against the
+// asyncSink-wrapped stderr/stdout writers installed by enableAsync
+// re-introducing the real-fd-closing bug fixed for the plain Init path:
+// InitWithOptions's defer l.Close() pattern (used throughout this file's
+// other tests) must stay safe to run against the process's real stderr.
+func TestInitWithOptionsCloseDoesNotCloseStderr(t *testing.T) {
+	old := os.Stderr
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stderr = w
+	defer func() { os.Stderr = old }()
+
+	var buf strings.Builder
+	l := InitWithOptions("async-test-close", false, []Sink{NewWriterSink(&buf)},
+		BufferSize(4096), FlushInterval(time.Hour), FlushSeverity(SeverityError))
+	l.Close()
+
+	if _, err := w.Write([]byte("still open\n")); err != nil {
+		t.Errorf("expected Close to leave the process's real os.Stderr open, got write error: %v", err)
+	}
+
+	w.Close()
+	r.Close()
+}
+
+func TestAsyncSinkDropsWithCountWhenFull(t *testing.T) {
+	var buf strings.Builder
+	sink := newAsyncSink(&buf, 8, time.Hour)
+	defer sink.close()
+
+	sink.Write([]byte("12345678"))
+	sink.Write([]byte("overflow"))
+	sink.flush()
+
+	if !strings.Contains(buf.String(), "messages dropped") {
+		t.Errorf("expected a drop-count record once the buffer overflowed, got %q", buf.String())
+	}
+}
+
+func BenchmarkLoggerSync(b *testing.B) {
+	var buf strings.Builder
+	l := Init("bench-sync", false, NewWriterSink(&buf))
+	defer l.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Info("benchmark message")
+	}
+}
+
+func BenchmarkLoggerAsync(b *testing.B) {
+	var buf strings.Builder
+	l := InitWithOptions("bench-async", false, []Sink{NewWriterSink(&buf)},
+		BufferSize(1<<20), FlushInterval(50*time.Millisecond), FlushSeverity(SeverityError))
+	defer l.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Info("benchmark message")
+	}
+}
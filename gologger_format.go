@@ -0,0 +1,159 @@
+package gologger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
+// Record is the fully-resolved representation of one log line, passed to a
+// Formatter. Caller is resolved once per record via runtime.Caller rather
+// than relying on log.Lshortfile, so it stays correct even when the record
+// is written asynchronously or to multiple sinks.
+type Record struct {
+	Severity  Severity
+	Timestamp time.Time
+	Caller    string
+	Message   string
+	KV        []interface{}
+}
+
+// Formatter renders a Record as the bytes written to a Logger's sinks.
+type Formatter interface {
+	Format(rec Record) []byte
+}
+
+// SetFormatter installs f as l's output formatter. Once set, output() and
+// outputS() render each record that reaches l's writers through f and write
+// the result to l's sinks (through the async buffer when InitWithOptions
+// enabled one) instead of through the default log.Logger text path. Records
+// that an installed logr backend takes over without WriteBuffer(true) never
+// reach f.
+func (l *Logger) SetFormatter(f Formatter) {
+	logLock.Lock()
+	defer logLock.Unlock()
+	l.formatter = f
+}
+
+// SetFormatter installs f as the default logger's output formatter.
+func SetFormatter(f Formatter) {
+	defaultLogger.SetFormatter(f)
+}
+
+// recordCallerSkip is the number of frames runtime.Caller skips from
+// buildRecord to reach the function that called output()/outputS()
+// (buildRecord, writeFormatted, output/outputS). output()/outputS() pass
+// their own depth plus one, so "depth 0" (the convention used throughout
+// this package) resolves to the caller of Info/InfoS/Warning/... themselves.
+const recordCallerSkip = 3
+
+// buildRecord resolves the caller depth frames above the function that
+// called output()/outputS() and assembles a Record; with the depth plus one
+// passed down by output()/outputS(), this follows the same depth convention
+// as InfoDepth et al.
+func buildRecord(s severity, depth int, msg string, kv []interface{}) Record {
+	caller := "???"
+	if _, file, line, ok := runtime.Caller(recordCallerSkip + depth); ok {
+		caller = fmt.Sprintf("%s:%d", filepath.Base(file), line)
+	}
+	return Record{
+		Severity:  Severity(s),
+		Timestamp: time.Now(),
+		Caller:    caller,
+		Message:   msg,
+		KV:        kv,
+	}
+}
+
+// writeFormatted renders msg/kv through l.formatter and writes the result
+// to severityWriter(s), bypassing the log.Logger text path entirely but
+// still going through whatever enableAsync installed there, so a Formatter
+// doesn't silently defeat BufferSize/FlushInterval/FlushSeverity. Callers
+// must hold logLock and must only call this when l.formatter is set.
+func (l *Logger) writeFormatted(s severity, depth int, msg string, kv []interface{}) {
+	rec := buildRecord(s, depth, msg, kv)
+	data := l.formatter.Format(rec)
+	if _, err := l.severityWriter(s).Write(data); err != nil {
+		logSinkError("write", err)
+	}
+}
+
+// severityWriter returns the io.Writer that backs severity s: the
+// sinkFanout installed by Init, or the asyncSink enableAsync wrapped it in
+// when s falls below the configured FlushSeverity.
+func (l *Logger) severityWriter(s severity) io.Writer {
+	switch s {
+	case sInfo:
+		return l.infoLog.Writer()
+	case sWarn:
+		return l.warnLog.Writer()
+	case sError:
+		return l.errorLog.Writer()
+	case sFatal:
+		return l.fatalLog.Writer()
+	default:
+		panic(fmt.Sprintln("[FATAL]: Unrecognized Severity:", s))
+	}
+}
+
+var severityLabel = map[Severity]string{
+	SeverityInfo:    "INFO",
+	SeverityWarning: "WARN",
+	SeverityError:   "ERROR",
+	SeverityFatal:   "FATAL",
+}
+
+// TextFormatter renders records in gologger's traditional
+// "[LEVEL]: date time caller: msg key=value ..." form, the same shape
+// output() produced before Formatters existed.
+type TextFormatter struct{}
+
+// Format implements Formatter.
+func (TextFormatter) Format(rec Record) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "[%s]: %s %s: %s%s\n",
+		severityLabel[rec.Severity],
+		rec.Timestamp.Format("2006/01/02 15:04:05.000000"),
+		rec.Caller,
+		rec.Message,
+		kvString(rec.KV))
+	return b.Bytes()
+}
+
+// JSONFormatter renders one JSON object per record with a stable schema,
+// suitable for ingestion by log pipelines (Loki, ELK, Cloud Logging, ...)
+// without having to parse gologger's text format.
+type JSONFormatter struct{}
+
+type jsonRecord struct {
+	Timestamp string                 `json:"ts"`
+	Severity  string                 `json:"severity"`
+	Caller    string                 `json:"caller"`
+	Message   string                 `json:"msg"`
+	KV        map[string]interface{} `json:"kv,omitempty"`
+}
+
+// Format implements Formatter.
+func (JSONFormatter) Format(rec Record) []byte {
+	kv := make(map[string]interface{}, len(rec.KV)/2)
+	for i := 0; i+1 < len(rec.KV); i += 2 {
+		kv[fmt.Sprint(rec.KV[i])] = rec.KV[i+1]
+	}
+
+	data, err := json.Marshal(jsonRecord{
+		Timestamp: rec.Timestamp.UTC().Format(time.RFC3339Nano),
+		Severity:  severityLabel[rec.Severity],
+		Caller:    rec.Caller,
+		Message:   rec.Message,
+		KV:        kv,
+	})
+	if err != nil {
+		return []byte(fmt.Sprintf("{\"ts\":%q,\"severity\":\"ERROR\",\"msg\":\"failed to marshal log record: %v\"}\n",
+			rec.Timestamp.UTC().Format(time.RFC3339Nano), err))
+	}
+	return append(data, '\n')
+}
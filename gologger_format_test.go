@@ -0,0 +1,53 @@
+package gologger
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestJSONFormatterCallerAndKV(t *testing.T) {
+	var buf strings.Builder
+	l := Init("format-test", false, NewWriterSink(&buf))
+	l.SetFormatter(JSONFormatter{})
+
+	l.InfoS("starting up", "port", 8080)
+
+	var rec jsonRecord
+	if err := json.Unmarshal([]byte(strings.TrimSpace(buf.String())), &rec); err != nil {
+		t.Fatalf("failed to unmarshal JSON record %q: %v", buf.String(), err)
+	}
+
+	if rec.Message != "starting up" {
+		t.Errorf("Message = %q, want %q", rec.Message, "starting up")
+	}
+	if rec.Severity != "INFO" {
+		t.Errorf("Severity = %q, want %q", rec.Severity, "INFO")
+	}
+	if matched, _ := regexp.MatchString(`^gologger_format_test\.go:\d+$`, rec.Caller); !matched {
+		t.Errorf("Caller = %q, want it to point at this test file (gologger_format_test.go:<line>)", rec.Caller)
+	}
+	if rec.KV["port"] != float64(8080) {
+		t.Errorf("KV[port] = %v, want 8080", rec.KV["port"])
+	}
+}
+
+func TestTextFormatterMatchesTraditionalShape(t *testing.T) {
+	var buf strings.Builder
+	l := Init("format-test-text", false, NewWriterSink(&buf))
+	l.SetFormatter(TextFormatter{})
+
+	l.Info("hello there")
+
+	out := buf.String()
+	if !strings.HasPrefix(out, "[INFO]: ") {
+		t.Errorf("expected TextFormatter output to start with the usual [INFO]: label, got %q", out)
+	}
+	if !strings.Contains(out, "gologger_format_test.go") {
+		t.Errorf("expected TextFormatter output to include the caller file, got %q", out)
+	}
+	if !strings.Contains(out, "hello there") {
+		t.Errorf("expected TextFormatter output to include the message, got %q", out)
+	}
+}
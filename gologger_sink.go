@@ -0,0 +1,132 @@
+package gologger
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// logSinkError reports a failure from a Sink's Write/Close/Flush to
+// stderr, mirroring how Close already reported closer errors before Sinks
+// existed.
+func logSinkError(op string, err error) {
+	fmt.Fprintf(os.Stderr, "[ERROR]: sink %s failed: %v\n", op, err)
+}
+
+// Sink is a log destination. Init composes one or more Sinks instead of
+// writing to a single hard-coded io.Writer, so callers can fan a Logger out
+// to a file, syslog, journald, and/or arbitrary writers simultaneously,
+// each filtered to the severities it cares about.
+type Sink interface {
+	// Write delivers one already-formatted log record at severity s. A
+	// Sink that doesn't want records at s (see MinSeverity/MaxSeverity)
+	// should return nil without writing.
+	Write(s Severity, p []byte) error
+	// Close releases any resources held by the sink.
+	Close() error
+	// Flush writes out anything the sink has buffered internally.
+	Flush() error
+}
+
+// SinkOption configures the severity range a built-in Sink accepts.
+type SinkOption func(*severityRange)
+
+// severityRange is embedded by the built-in sinks to implement the
+// MinSeverity/MaxSeverity filtering common to all of them.
+type severityRange struct {
+	min Severity
+	max Severity
+}
+
+func defaultSeverityRange() severityRange {
+	return severityRange{min: SeverityInfo, max: SeverityFatal}
+}
+
+func (r severityRange) accepts(s Severity) bool {
+	return s >= r.min && s <= r.max
+}
+
+// MinSeverity restricts a built-in sink to severities at or above s.
+func MinSeverity(s Severity) SinkOption {
+	return func(r *severityRange) {
+		r.min = s
+	}
+}
+
+// MaxSeverity restricts a built-in sink to severities at or below s.
+func MaxSeverity(s Severity) SinkOption {
+	return func(r *severityRange) {
+		r.max = s
+	}
+}
+
+// WriterSink adapts an arbitrary io.Writer to the Sink interface. If w
+// implements io.Closer or `Flush() error`, Close/Flush delegate to it.
+type WriterSink struct {
+	severityRange
+	w io.Writer
+}
+
+// NewWriterSink wraps w as a Sink, writing every record whose severity
+// falls within the range configured by opts (the default is every
+// severity).
+func NewWriterSink(w io.Writer, opts ...SinkOption) *WriterSink {
+	s := &WriterSink{severityRange: defaultSeverityRange(), w: w}
+	for _, opt := range opts {
+		opt(&s.severityRange)
+	}
+	return s
+}
+
+// Write implements Sink.
+func (s *WriterSink) Write(sev Severity, p []byte) error {
+	if !s.accepts(sev) {
+		return nil
+	}
+	_, err := s.w.Write(p)
+	return err
+}
+
+// Close implements Sink, delegating to w if it is an io.Closer.
+func (s *WriterSink) Close() error {
+	if c, ok := s.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Flush implements Sink, delegating to w if it exposes a Flush() error
+// method.
+func (s *WriterSink) Flush() error {
+	if f, ok := s.w.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// nopCloseWriter wraps an io.Writer to hide any io.Closer or `Flush() error`
+// method its underlying concrete type implements, so a WriterSink built
+// around it never closes or flushes w. Init uses this for the
+// stderr/stdout sinks it adds automatically, since those file descriptors
+// belong to the process for its whole lifetime, not to the Logger that
+// happens to write to them.
+type nopCloseWriter struct {
+	io.Writer
+}
+
+// sinkFanout adapts a Logger's configured sinks to the io.Writer interface
+// expected by log.Logger, so that a single Output() call at a given
+// severity is delivered to every sink whose range accepts that severity.
+type sinkFanout struct {
+	severity Severity
+	sinks    []Sink
+}
+
+func (f *sinkFanout) Write(p []byte) (int, error) {
+	for _, s := range f.sinks {
+		if err := s.Write(f.severity, p); err != nil {
+			logSinkError("write", err)
+		}
+	}
+	return len(p), nil
+}
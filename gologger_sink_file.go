@@ -0,0 +1,226 @@
+package gologger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FileSinkOption configures a FileSink's rotation behavior and severity
+// range.
+type FileSinkOption func(*FileSink)
+
+// WithMaxSizeBytes rotates the file once it would grow past n bytes. The
+// default, 0, disables size-based rotation.
+func WithMaxSizeBytes(n int64) FileSinkOption {
+	return func(f *FileSink) {
+		f.maxSizeBytes = n
+	}
+}
+
+// WithMaxBackups keeps at most n rotated files, removing the oldest first.
+// The default, 0, keeps every rotated file.
+func WithMaxBackups(n int) FileSinkOption {
+	return func(f *FileSink) {
+		f.maxBackups = n
+	}
+}
+
+// WithMaxAgeDays removes rotated files older than n days, independent of
+// WithMaxBackups. The default, 0, disables age-based cleanup.
+func WithMaxAgeDays(n int) FileSinkOption {
+	return func(f *FileSink) {
+		f.maxAgeDays = n
+	}
+}
+
+// WithCompress gzips rotated files as they're created.
+func WithCompress(enabled bool) FileSinkOption {
+	return func(f *FileSink) {
+		f.compress = enabled
+	}
+}
+
+// WithSeverityRange restricts a FileSink to the severities accepted by
+// opts (e.g. WithSeverityRange(MinSeverity(SeverityWarning))).
+func WithSeverityRange(opts ...SinkOption) FileSinkOption {
+	return func(f *FileSink) {
+		for _, opt := range opts {
+			opt(&f.severityRange)
+		}
+	}
+}
+
+// FileSink writes records to a file, rotating it by size and pruning old
+// rotations by count and/or age, in the spirit of klog's
+// -log_file/-log_file_max_size but with lumberjack-style rotation.
+type FileSink struct {
+	severityRange
+
+	path         string
+	maxSizeBytes int64
+	maxBackups   int
+	maxAgeDays   int
+	compress     bool
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// NewFileSink opens (creating if necessary) the file at path for appending,
+// configured by opts.
+func NewFileSink(path string, opts ...FileSinkOption) (*FileSink, error) {
+	f := &FileSink{severityRange: defaultSeverityRange(), path: path}
+	for _, opt := range opts {
+		opt(f)
+	}
+	if err := f.openExisting(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *FileSink) openExisting() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+// Write implements Sink.
+func (f *FileSink) Write(sev Severity, p []byte) error {
+	if !f.accepts(sev) {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.maxSizeBytes > 0 && f.size+int64(len(p)) > f.maxSizeBytes {
+		if err := f.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return err
+}
+
+// rotate renames the current file aside (optionally gzipping it), opens a
+// fresh file at the original path, and prunes old rotations. Callers must
+// hold f.mu.
+func (f *FileSink) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+
+	backupPath := fmt.Sprintf("%s.%s", f.path, time.Now().Format("20060102T150405"))
+	if err := os.Rename(f.path, backupPath); err != nil {
+		return err
+	}
+	if f.compress {
+		if err := gzipFile(backupPath); err != nil {
+			return err
+		}
+	}
+
+	if err := f.openExisting(); err != nil {
+		return err
+	}
+	f.size = 0
+
+	return f.prune()
+}
+
+func gzipFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return err
+	}
+	gw := gzip.NewWriter(dst)
+	if _, err := io.Copy(gw, src); err != nil {
+		gw.Close()
+		dst.Close()
+		return err
+	}
+	if err := gw.Close(); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// prune removes rotated files beyond maxBackups and/or older than
+// maxAgeDays. Callers must hold f.mu.
+func (f *FileSink) prune() error {
+	if f.maxBackups <= 0 && f.maxAgeDays <= 0 {
+		return nil
+	}
+
+	matches, err := filepath.Glob(f.path + ".*")
+	if err != nil {
+		return err
+	}
+
+	type backup struct {
+		path    string
+		modTime time.Time
+	}
+	var backups []backup
+	for _, m := range matches {
+		info, err := os.Stat(m)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backup{path: m, modTime: info.ModTime()})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].modTime.After(backups[j].modTime)
+	})
+
+	cutoff := time.Now().AddDate(0, 0, -f.maxAgeDays)
+	for i, b := range backups {
+		tooOld := f.maxAgeDays > 0 && b.modTime.Before(cutoff)
+		tooMany := f.maxBackups > 0 && i >= f.maxBackups
+		if tooOld || tooMany {
+			os.Remove(b.path)
+		}
+	}
+	return nil
+}
+
+// Close implements Sink.
+func (f *FileSink) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
+
+// Flush implements Sink by syncing the file to disk.
+func (f *FileSink) Flush() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Sync()
+}
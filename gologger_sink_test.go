@@ -0,0 +1,70 @@
+package gologger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriterSinkSeverityRange(t *testing.T) {
+	var buf strings.Builder
+	s := NewWriterSink(&buf, MinSeverity(SeverityWarning), MaxSeverity(SeverityError))
+
+	if err := s.Write(SeverityInfo, []byte("info\n")); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected Info to be filtered out, got %q", buf.String())
+	}
+
+	if err := s.Write(SeverityWarning, []byte("warn\n")); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "warn") {
+		t.Errorf("expected Warning within range to be written, got %q", buf.String())
+	}
+}
+
+func TestInitComposesUserSinks(t *testing.T) {
+	var buf strings.Builder
+	l := Init("sink-test", false, NewWriterSink(&buf))
+	defer l.Close()
+
+	l.Info("via sink")
+	if !strings.Contains(buf.String(), "via sink") {
+		t.Errorf("expected user-provided sink to receive output, got %q", buf.String())
+	}
+}
+
+func TestFileSinkRotatesBySize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.log")
+
+	sink, err := NewFileSink(path, WithMaxSizeBytes(10), WithMaxBackups(2))
+	if err != nil {
+		t.Fatalf("NewFileSink returned error: %v", err)
+	}
+	defer sink.Close()
+
+	for i := 0; i < 5; i++ {
+		if err := sink.Write(SeverityInfo, []byte("0123456789\n")); err != nil {
+			t.Fatalf("Write returned error: %v", err)
+		}
+	}
+
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		t.Fatalf("Glob returned error: %v", err)
+	}
+	if len(matches) == 0 {
+		t.Error("expected at least one rotated backup file")
+	}
+	if len(matches) > 2 {
+		t.Errorf("expected MaxBackups to cap rotated files at 2, found %d", len(matches))
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("expected current log file to still exist: %v", err)
+	}
+}
@@ -0,0 +1,211 @@
+package gologger
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+)
+
+// LoggerOption configures a Logger when installing a logr backend via
+// SetLogger.
+type LoggerOption func(*Logger)
+
+// WriteBuffer controls whether formatted output keeps being written to the
+// Logger's underlying writers after a backend is installed with SetLogger.
+// By default, installing a backend suppresses the log.Logger writers (and
+// their "[LEVEL]: file:line" header) in favor of the backend; passing
+// WriteBuffer(true) keeps mirroring output to both.
+func WriteBuffer(enabled bool) LoggerOption {
+	return func(l *Logger) {
+		l.writeBuffer = enabled
+	}
+}
+
+// SetLogger installs logger as the backend for l. Once installed, output
+// produced by Info/Warning/Error/Fatal and the structured *S methods is
+// routed through the backend's Info/Error methods instead of l's own
+// writers, unless WriteBuffer(true) is passed.
+func (l *Logger) SetLogger(logger logr.Logger, opts ...LoggerOption) {
+	logLock.Lock()
+	defer logLock.Unlock()
+	l.backend = logger
+	l.hasBackend = true
+	for _, opt := range opts {
+		opt(l)
+	}
+}
+
+// SetLogger installs logger as the backend for the default logger. See
+// Logger.SetLogger for details.
+func SetLogger(logger logr.Logger, opts ...LoggerOption) {
+	defaultLogger.SetLogger(logger, opts...)
+}
+
+// clone returns a shallow copy of l, suitable as the basis for a child
+// Logger returned from WithValues/WithName.
+func (l *Logger) clone() *Logger {
+	c := *l
+	c.values = append([]interface{}{}, l.values...)
+	return &c
+}
+
+// WithValues returns a child Logger that carries kv in addition to any
+// values already attached to l. kv is interpreted as alternating key/value
+// pairs, matching logr's convention.
+func (l *Logger) WithValues(kv ...interface{}) *Logger {
+	c := l.clone()
+	c.values = append(c.values, kv...)
+	if l.hasBackend {
+		c.backend = l.backend.WithValues(kv...)
+	}
+	return c
+}
+
+// WithValues returns a child of the default logger. See Logger.WithValues.
+func WithValues(kv ...interface{}) *Logger {
+	return defaultLogger.WithValues(kv...)
+}
+
+// WithName returns a child Logger whose name is name, appended to any name
+// already set on l with a "." separator, matching logr's WithName.
+func (l *Logger) WithName(name string) *Logger {
+	c := l.clone()
+	if c.name == "" {
+		c.name = name
+	} else {
+		c.name = c.name + "." + name
+	}
+	if l.hasBackend {
+		c.backend = l.backend.WithName(name)
+	}
+	return c
+}
+
+// WithName returns a named child of the default logger. See Logger.WithName.
+func WithName(name string) *Logger {
+	return defaultLogger.WithName(name)
+}
+
+// outputS is the shared implementation behind InfoS, WarningS and ErrorS. It
+// delivers the record to the backend (if installed) and, unless the backend
+// has fully taken over via WriteBuffer(false) semantics, also renders kv
+// into txt and writes it through the usual text path.
+func (l *Logger) outputS(s severity, depth int, err error, msg string, keysAndValues []interface{}) {
+	logLock.Lock()
+	defer logLock.Unlock()
+	if l.hasBackend {
+		l.deliverToBackend(s, err, msg, keysAndValues)
+		if !l.writeBuffer {
+			return
+		}
+	}
+	allKV := append(append([]interface{}{}, l.values...), keysAndValues...)
+	if err != nil {
+		allKV = append(allKV, "err", err)
+	}
+
+	if l.formatter != nil {
+		l.writeFormatted(s, depth+1, msg, allKV)
+		return
+	}
+	l.writeText(s, depth+1, msg+kvString(allKV))
+}
+
+// kvString renders keysAndValues (alternating key/value pairs) as
+// " key1=value1 key2=value2 ..." for the text output path.
+func kvString(keysAndValues []interface{}) string {
+	if len(keysAndValues) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		fmt.Fprintf(&b, " %v=%v", keysAndValues[i], keysAndValues[i+1])
+	}
+	if len(keysAndValues)%2 == 1 {
+		fmt.Fprintf(&b, " %v=(MISSING)", keysAndValues[len(keysAndValues)-1])
+	}
+	return b.String()
+}
+
+/** STRUCTURED INFO/WARNING/ERROR LOGS **/
+
+// InfoS logs a structured info message, msg, along with the alternating
+// key/value pairs in keysAndValues.
+func (l *Logger) InfoS(msg string, keysAndValues ...interface{}) {
+	l.outputS(sInfo, 0, nil, msg, keysAndValues)
+}
+
+// InfoS logs a structured info message on the default logger.
+func InfoS(msg string, keysAndValues ...interface{}) {
+	defaultLogger.outputS(sInfo, 0, nil, msg, keysAndValues)
+}
+
+// WarningS logs a structured warning message, msg, along with the
+// alternating key/value pairs in keysAndValues.
+func (l *Logger) WarningS(msg string, keysAndValues ...interface{}) {
+	l.outputS(sWarn, 0, nil, msg, keysAndValues)
+}
+
+// WarningS logs a structured warning message on the default logger.
+func WarningS(msg string, keysAndValues ...interface{}) {
+	defaultLogger.outputS(sWarn, 0, nil, msg, keysAndValues)
+}
+
+// ErrorS logs a structured error message, msg, along with err and the
+// alternating key/value pairs in keysAndValues.
+func (l *Logger) ErrorS(err error, msg string, keysAndValues ...interface{}) {
+	l.outputS(sError, 0, err, msg, keysAndValues)
+}
+
+// ErrorS logs a structured error message on the default logger.
+func ErrorS(err error, msg string, keysAndValues ...interface{}) {
+	defaultLogger.outputS(sError, 0, err, msg, keysAndValues)
+}
+
+/** CONTEXT-SCOPED LOGGER **/
+
+// loggerContextKey is an unexported type to avoid collisions with context
+// keys defined in other packages.
+type loggerContextKey struct{}
+
+// NewContext returns a copy of ctx carrying l, retrievable with FromContext.
+func NewContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, l)
+}
+
+// FromContext returns the Logger stored in ctx by NewContext, if any, and
+// whether one was found. Callers that want a usable Logger regardless
+// should fall back to the default logger when ok is false.
+func FromContext(ctx context.Context) (l *Logger, ok bool) {
+	l, ok = ctx.Value(loggerContextKey{}).(*Logger)
+	return l, ok
+}
+
+// loggerFromContext returns the Logger in ctx, falling back to the default
+// logger when ctx carries none.
+func loggerFromContext(ctx context.Context) *Logger {
+	if l, ok := FromContext(ctx); ok {
+		return l
+	}
+	return defaultLogger
+}
+
+// InfoSContext is InfoS using the Logger found in ctx, falling back to the
+// default logger when ctx carries none.
+func InfoSContext(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	loggerFromContext(ctx).outputS(sInfo, 0, nil, msg, keysAndValues)
+}
+
+// WarningSContext is WarningS using the Logger found in ctx, falling back to
+// the default logger when ctx carries none.
+func WarningSContext(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	loggerFromContext(ctx).outputS(sWarn, 0, nil, msg, keysAndValues)
+}
+
+// ErrorSContext is ErrorS using the Logger found in ctx, falling back to the
+// default logger when ctx carries none.
+func ErrorSContext(ctx context.Context, err error, msg string, keysAndValues ...interface{}) {
+	loggerFromContext(ctx).outputS(sError, 0, err, msg, keysAndValues)
+}
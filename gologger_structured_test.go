@@ -0,0 +1,52 @@
+package gologger
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+)
+
+func TestInfoSTextFallback(t *testing.T) {
+	var buf strings.Builder
+	l := Init("structured-test", false, NewWriterSink(&buf))
+
+	l.InfoS("starting up", "port", 8080)
+
+	out := buf.String()
+	if !strings.Contains(out, "starting up") || !strings.Contains(out, "port=8080") {
+		t.Errorf("InfoS output %q missing message or key/value pair", out)
+	}
+}
+
+func TestSetLoggerDelegatesAndSuppressesWriters(t *testing.T) {
+	var buf strings.Builder
+	l := Init("structured-test-backend", false, NewWriterSink(&buf))
+
+	var sink strings.Builder
+	backend := funcr.New(func(prefix, args string) {
+		sink.WriteString(prefix + args)
+	}, funcr.Options{})
+
+	l.SetLogger(backend)
+	l.InfoS("hello", "k", "v")
+
+	if buf.Len() != 0 {
+		t.Errorf("expected writer output to be suppressed once a backend is installed, got %q", buf.String())
+	}
+	if !strings.Contains(sink.String(), "hello") {
+		t.Errorf("expected backend to receive the record, got %q", sink.String())
+	}
+}
+
+func TestWithValuesInherited(t *testing.T) {
+	var buf strings.Builder
+	l := Init("structured-test-withvalues", false, NewWriterSink(&buf))
+
+	child := l.WithValues("request", "abc123")
+	child.InfoS("handled")
+
+	if !strings.Contains(buf.String(), "request=abc123") {
+		t.Errorf("expected inherited values in output, got %q", buf.String())
+	}
+}
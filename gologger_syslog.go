@@ -1,23 +1,77 @@
+//go:build unix
+
 package gologger
 
 import (
-	"io"
 	"log/syslog"
 )
 
-func setup(src string) (io.Writer, io.Writer, io.Writer, error) {
+// SyslogSink writes records to the local syslog daemon, tagged with the
+// given name. It is only built on unix, since log/syslog has no portable
+// equivalent elsewhere.
+type SyslogSink struct {
+	severityRange
+	info, warn, err *syslog.Writer
+}
+
+// NewSyslogSink opens a SyslogSink tagged as name, writing every record
+// whose severity falls within the range configured by opts (the default is
+// every severity). Warning is logged at LOG_WARNING, Error and Fatal at
+// LOG_ERR, and everything else at LOG_NOTICE.
+func NewSyslogSink(name string, opts ...SinkOption) (*SyslogSink, error) {
 	const facility = syslog.LOG_USER
-	il, err := syslog.New(facility|syslog.LOG_NOTICE, src)
+
+	info, err := syslog.New(facility|syslog.LOG_NOTICE, name)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, err
 	}
-	wl, err := syslog.New(facility|syslog.LOG_WARNING, src)
+	warn, err := syslog.New(facility|syslog.LOG_WARNING, name)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, err
 	}
-	el, err := syslog.New(facility|syslog.LOG_ERR, src)
+	errL, err := syslog.New(facility|syslog.LOG_ERR, name)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, err
 	}
-	return il, wl, el, nil
+
+	s := &SyslogSink{severityRange: defaultSeverityRange(), info: info, warn: warn, err: errL}
+	for _, opt := range opts {
+		opt(&s.severityRange)
+	}
+	return s, nil
+}
+
+// Write implements Sink.
+func (s *SyslogSink) Write(sev Severity, p []byte) error {
+	if !s.accepts(sev) {
+		return nil
+	}
+	var err error
+	switch sev {
+	case SeverityWarning:
+		_, err = s.warn.Write(p)
+	case SeverityError, SeverityFatal:
+		_, err = s.err.Write(p)
+	default:
+		_, err = s.info.Write(p)
+	}
+	return err
+}
+
+// Close implements Sink, closing all three underlying syslog connections
+// and returning the first error encountered.
+func (s *SyslogSink) Close() error {
+	var firstErr error
+	for _, w := range []*syslog.Writer{s.info, s.warn, s.err} {
+		if err := w.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Flush implements Sink. Syslog writes are unbuffered, so there is nothing
+// to do.
+func (s *SyslogSink) Flush() error {
+	return nil
 }
@@ -51,14 +51,14 @@ func TestLoggingBeforeInit(t *testing.T) {
 
 func TestInit(t *testing.T) {
 	var buf1 bytes.Buffer
-	l1 := Init("test1", false, false, &buf1)
+	l1 := Init("test1", false, NewWriterSink(&buf1))
 	if !reflect.DeepEqual(l1, defaultLogger) {
 		t.Fatal("defaultLogger does not match logger returned by Init")
 	}
 
 	// Subsequent runs of Init shouldn't change defaultLogger.
 	var buf2 bytes.Buffer
-	l2 := Init("test2", false, false, &buf2)
+	l2 := Init("test2", false, NewWriterSink(&buf2))
 	if !reflect.DeepEqual(l1, defaultLogger) {
 		t.Error("defaultLogger shouldn't have changed")
 	}
@@ -82,4 +82,40 @@ func TestInit(t *testing.T) {
 			t.Errorf("Logger %d mismatched no. of lines, expected %d, got %d", i+1, tt.want, got)
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestCloseDoesNotCloseStderr(t *testing.T) {
+	old := os.Stderr
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stderr = w
+	defer func() { os.Stderr = old }()
+
+	var buf bytes.Buffer
+	l := Init("close-test", false, NewWriterSink(&buf))
+	l.Close()
+
+	if _, err := w.Write([]byte("still open\n")); err != nil {
+		t.Errorf("expected Close to leave the process's real os.Stderr open, got write error: %v", err)
+	}
+
+	w.Close()
+	r.Close()
+}
+
+func TestInfoReportsCallerLine(t *testing.T) {
+	var buf bytes.Buffer
+	l := Init("caller-test", false, NewWriterSink(&buf))
+
+	l.Info("plain info")
+
+	out := buf.String()
+	if !strings.Contains(out, "gologger_test.go:") {
+		t.Errorf("expected Info to report its caller's line in this test file, got %q", out)
+	}
+	if strings.Contains(out, "gologger.go:") {
+		t.Errorf("expected Info not to report a line inside gologger.go itself, got %q", out)
+	}
+}
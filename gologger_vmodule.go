@@ -0,0 +1,188 @@
+package gologger
+
+import (
+	"fmt"
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// vmodulePattern is a single "pattern=level" entry parsed from a -vmodule
+// spec. pattern is matched against the base filename (without ".go"), the
+// full path reported by runtime.Caller, and, when pattern contains "/", the
+// same number of trailing path components (without ".go"), so "file=2" and
+// "dir/*=3" both work as in glog/klog.
+type vmodulePattern struct {
+	pattern string
+	level   Level
+}
+
+// vLevelEntry is cached per call site (keyed by PC) once a Logger's vmodule
+// patterns have been consulted, so later calls at the same site skip
+// pattern matching entirely.
+type vLevelEntry struct {
+	level   Level
+	matched bool
+}
+
+func (p vmodulePattern) matches(file string) bool {
+	base := file
+	if idx := strings.LastIndexByte(file, '/'); idx >= 0 {
+		base = file[idx+1:]
+	}
+	base = strings.TrimSuffix(base, ".go")
+	if ok, _ := path.Match(p.pattern, base); ok {
+		return true
+	}
+	if ok, _ := path.Match(p.pattern, file); ok {
+		return true
+	}
+	if n := strings.Count(p.pattern, "/") + 1; n > 1 {
+		parts := strings.Split(strings.TrimSuffix(file, ".go"), "/")
+		if len(parts) >= n {
+			if ok, _ := path.Match(p.pattern, strings.Join(parts[len(parts)-n:], "/")); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseVModule parses a comma-separated "pattern=level" spec, as accepted
+// by SetVModule.
+func parseVModule(spec string) ([]vmodulePattern, error) {
+	var pats []vmodulePattern
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("gologger: invalid vmodule entry %q, want pattern=level", entry)
+		}
+		lvl, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("gologger: invalid vmodule level in %q: %v", entry, err)
+		}
+		pats = append(pats, vmodulePattern{pattern: parts[0], level: Level(lvl)})
+	}
+	return pats, nil
+}
+
+// SetVModule configures per-file/per-package verbosity overrides, mirroring
+// glog/klog's -vmodule flag. spec is a comma-separated list of
+// "pattern=level" entries, where pattern matches either the base filename
+// without ".go" (e.g. "gologger") or a path glob (e.g. "internal/*"). A
+// call site whose file matches a pattern uses that pattern's level instead
+// of l.level when evaluating Verbosity/VDepth.
+func (l *Logger) SetVModule(spec string) error {
+	pats, err := parseVModule(spec)
+	if err != nil {
+		return err
+	}
+	logLock.Lock()
+	l.vmodule = pats
+	l.siteCache = &sync.Map{}
+	logLock.Unlock()
+	return nil
+}
+
+// SetVModule configures per-file/per-package verbosity overrides for the
+// default logger. See Logger.SetVModule.
+func SetVModule(spec string) error {
+	return defaultLogger.SetVModule(spec)
+}
+
+// effectiveLevel resolves the Level that applies to the call site skip
+// frames above its own caller, consulting the Logger's vmodule patterns and
+// per-site cache. ok is false when no pattern matches (or none are
+// configured), meaning the caller should fall back to l.level.
+func (l *Logger) effectiveLevel(skip int) (lvl Level, ok bool) {
+	logLock.Lock()
+	pats := l.vmodule
+	cache := l.siteCache
+	logLock.Unlock()
+	if len(pats) == 0 || cache == nil {
+		return 0, false
+	}
+
+	pc, file, _, callerOK := runtime.Caller(skip + 1)
+	if !callerOK {
+		return 0, false
+	}
+	if v, found := cache.Load(pc); found {
+		entry := v.(vLevelEntry)
+		return entry.level, entry.matched
+	}
+
+	for _, p := range pats {
+		if p.matches(file) {
+			cache.Store(pc, vLevelEntry{level: p.level, matched: true})
+			return p.level, true
+		}
+	}
+	cache.Store(pc, vLevelEntry{matched: false})
+	return 0, false
+}
+
+// VDepth acts as Verbosity but uses depth to determine which call frame's
+// source file is matched against -vmodule patterns. VDepth called with
+// depth 0 is equivalent to Verbosity.
+func (l *Logger) VDepth(depth int, lvl Level) Verbose {
+	level := l.level
+	if eff, ok := l.effectiveLevel(depth + 1); ok {
+		level = eff
+	}
+	return Verbose{
+		enabled: level >= lvl,
+		logger:  l,
+	}
+}
+
+// VDepth acts as Verbosity but uses depth to determine which call frame's
+// source file is matched against -vmodule patterns, using the default
+// logger.
+func VDepth(depth int, lvl Level) Verbose {
+	return defaultLogger.VDepth(depth+1, lvl)
+}
+
+// VModuleFlag adapts a Logger's SetVModule to the flag.Value interface, so
+// -vmodule can be wired up directly, e.g.:
+//
+//	flag.Var(gologger.NewVModuleFlag(nil), "vmodule", "comma-separated list of pattern=N settings")
+type VModuleFlag struct {
+	logger *Logger
+}
+
+// NewVModuleFlag returns a VModuleFlag that configures l. A nil l targets
+// the default logger.
+func NewVModuleFlag(l *Logger) *VModuleFlag {
+	if l == nil {
+		l = defaultLogger
+	}
+	return &VModuleFlag{logger: l}
+}
+
+// String returns the current -vmodule spec, satisfying flag.Value.
+func (f *VModuleFlag) String() string {
+	if f == nil || f.logger == nil {
+		return ""
+	}
+	logLock.Lock()
+	pats := f.logger.vmodule
+	logLock.Unlock()
+
+	parts := make([]string, 0, len(pats))
+	for _, p := range pats {
+		parts = append(parts, fmt.Sprintf("%s=%d", p.pattern, p.level))
+	}
+	return strings.Join(parts, ",")
+}
+
+// Set parses and installs spec, satisfying flag.Value.
+func (f *VModuleFlag) Set(spec string) error {
+	return f.logger.SetVModule(spec)
+}
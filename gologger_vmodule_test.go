@@ -0,0 +1,31 @@
+package gologger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetVModuleOverridesPerFile(t *testing.T) {
+	var buf strings.Builder
+	l := Init("vmodule-test", false, NewWriterSink(&buf))
+
+	if err := l.SetVModule("gologger_vmodule_test=2"); err != nil {
+		t.Fatalf("SetVModule returned error: %v", err)
+	}
+
+	if !l.Verbosity(2).enabled {
+		t.Error("expected Verbosity(2) to be enabled once this file's pattern matches at level 2")
+	}
+	if l.Verbosity(3).enabled {
+		t.Error("expected Verbosity(3) to stay disabled above the matched level")
+	}
+}
+
+func TestSetVModuleInvalidSpec(t *testing.T) {
+	var buf strings.Builder
+	l := Init("vmodule-test-invalid", false, NewWriterSink(&buf))
+
+	if err := l.SetVModule("no-equals-sign"); err == nil {
+		t.Error("expected an error for a spec missing '=level'")
+	}
+}